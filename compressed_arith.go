@@ -16,9 +16,40 @@ func MulMatRawVec(lhs *CSR, rhs []float64, out []float64) {
 		panic(mat.ErrShape)
 	}
 
+	if lhs.symmetry != Full {
+		mulSymmetricMatRawVec(lhs, rhs, out)
+		return
+	}
+
 	blas.Dusmv(false, 1, lhs.RawMatrix(), rhs, 1, out, 1)
 }
 
+// mulSymmetricMatRawVec computes the matrix vector product for a CSR
+// flagged LowerSymmetric/UpperSymmetric/SkewSymmetric, where only one
+// triangle of the matrix is physically stored. Every stored A[i][j]
+// contributes out[i] += A[i][j]*x[j] and, unless it lies on the diagonal,
+// the implied mirrored element also contributes
+// out[j] += sign*A[i][j]*x[i] in the same pass - sign is -1 for
+// SkewSymmetric and +1 otherwise.
+func mulSymmetricMatRawVec(lhs *CSR, rhs []float64, out []float64) {
+	raw := lhs.RawMatrix()
+	sign := 1.0
+	if lhs.symmetry == SkewSymmetric {
+		sign = -1.0
+	}
+
+	for i := 0; i < raw.I; i++ {
+		for p := raw.Indptr[i]; p < raw.Indptr[i+1]; p++ {
+			j := raw.Ind[p]
+			v := raw.Data[p]
+			out[i] += v * rhs[j]
+			if j != i {
+				out[j] += sign * v * rhs[i]
+			}
+		}
+	}
+}
+
 // temporaryWorkspace returns a new CSR matrix w with the size of r x c with
 // initial capacity allocated for nnz non-zero elements and
 // returns a callback to defer which performs cleanup at the return of the call.
@@ -70,6 +101,11 @@ func (c *CSR) spalloc(a mat.Matrix, b mat.Matrix) (m *CSR, isTemp bool, restore
 // sparse matrix multiplication.
 // If the number of columns does not equal the number of rows in b, Mul will panic.
 func (c *CSR) Mul(a, b mat.Matrix) {
+	// mulCSRCSR and the other Mul fast paths below have no symmetry
+	// awareness, so operands must always be expanded regardless of any
+	// symmetry tag carried by the receiver itself.
+	a, b = c.reconcileSymmetry(a, b, false)
+
 	ar, ac := a.Dims()
 	br, bc := b.Dims()
 
@@ -84,6 +120,15 @@ func (c *CSR) Mul(a, b mat.Matrix) {
 
 	lhs, isLCsr := a.(*CSR)
 	rhs, isRCsr := b.(*CSR)
+	if lCoo, ok := a.(*COO); ok {
+		// COO.ToCSR takes the row-sorted fast path itself, so converting
+		// here avoids falling through to the generic mat.Matrix *
+		// mat.Matrix path below, whose inner loop calls At repeatedly.
+		lhs, isLCsr = lCoo.ToCSR(), true
+	}
+	if rCoo, ok := b.(*COO); ok {
+		rhs, isRCsr = rCoo.ToCSR(), true
+	}
 	if isLCsr && isRCsr {
 		// handle CSR * CSR
 		c.mulCSRCSR(lhs, rhs)
@@ -91,6 +136,11 @@ func (c *CSR) Mul(a, b mat.Matrix) {
 	}
 
 	if dia, ok := a.(*DIA); ok {
+		if diaB, ok := b.(*DIA); ok {
+			// handle DIA * DIA
+			c.mulDIADIA(dia, diaB)
+			return
+		}
 		if isRCsr {
 			// handle DIA * CSR
 			c.mulDIACSR(dia, rhs, false)
@@ -110,7 +160,41 @@ func (c *CSR) Mul(a, b mat.Matrix) {
 		c.mulDIAMat(dia, a, true)
 		return
 	}
-	// TODO: handle cases where both matrices are DIA
+
+	if ell, ok := a.(*ELL); ok {
+		if _, vc := b.Dims(); vc == 1 {
+			// ELL * dense vector: use the fixed-width SpMV directly
+			// instead of converting through CSR.
+			rhs := getFloats(ell.c, false)
+			defer putFloats(rhs)
+			for i := range rhs {
+				rhs[i] = b.At(i, 0)
+			}
+			out := getFloats(ell.r, false)
+			defer putFloats(out)
+			MulVecELL(ell, rhs, out)
+			for i, v := range out {
+				if v != 0 {
+					c.matrix.Ind = append(c.matrix.Ind, 0)
+					c.matrix.Data = append(c.matrix.Data, v)
+				}
+				c.matrix.Indptr[i+1] = len(c.matrix.Ind)
+			}
+			return
+		}
+		if isRCsr {
+			c.mulCSRCSR(ell.ToCSR(), rhs)
+			return
+		}
+		c.mulCSRMat(ell.ToCSR(), b)
+		return
+	}
+	if ell, ok := b.(*ELL); ok && isLCsr {
+		// CSR * ELL: convert the ELL operand once rather than falling
+		// through the general Sparser * Sparser path below.
+		c.mulCSRCSR(lhs, ell.ToCSR())
+		return
+	}
 
 	srcA, isLSparse := a.(TypeConverter)
 	srcB, isRSparse := b.(TypeConverter)
@@ -283,6 +367,11 @@ func (c *CSR) Add(a, b mat.Matrix) {
 
 // addScaled adds matrices a and b scaling them by a and b respectively before hand.
 func (c *CSR) addScaled(a mat.Matrix, b mat.Matrix, alpha float64, beta float64) {
+	// addCSRCSR/addCSR only union sparsity patterns, so a receiver
+	// already flagged with the same symmetric mode as both operands can
+	// skip expansion and keep the result half-stored.
+	a, b = c.reconcileSymmetry(a, b, true)
+
 	ar, ac := a.Dims()
 	br, bc := b.Dims()
 
@@ -297,7 +386,22 @@ func (c *CSR) addScaled(a mat.Matrix, b mat.Matrix, alpha float64, beta float64)
 
 	lCsr, lIsCsr := a.(*CSR)
 	rCsr, rIsCsr := b.(*CSR)
-	// TODO optimisation for DIA matrices
+	if lCoo, ok := a.(*COO); ok {
+		// COO.ToCSR takes the row-sorted fast path itself, so this avoids a
+		// full sort/coalesce pass when the COO was already assembled in
+		// row-major order.
+		lCsr, lIsCsr = lCoo.ToCSR(), true
+	}
+	if rCoo, ok := b.(*COO); ok {
+		rCsr, rIsCsr = rCoo.ToCSR(), true
+	}
+	if lDia, ok := a.(*DIA); ok {
+		if rDia, ok := b.(*DIA); ok {
+			// handle DIA +/- DIA
+			c.addDIADIA(lDia, rDia, alpha, beta)
+			return
+		}
+	}
 	if lIsCsr && rIsCsr {
 		c.addCSRCSR(lCsr, rCsr, alpha, beta)
 		return
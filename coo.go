@@ -0,0 +1,282 @@
+package sparse
+
+import (
+	"sort"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// COO is a sparse matrix representation that stores non-zero elements as an
+// unordered list of (row, column, value) triplets.  It is the natural entry
+// point for incrementally assembling a sparse matrix, e.g. when building a
+// stiffness matrix from a finite element mesh or an adjacency matrix from an
+// edge list, where elements are discovered one at a time and duplicate
+// (row, column) pairs should be summed together rather than overwritten.
+//
+// Once assembly is complete, a COO is typically converted to CSR or CSC
+// (via ToCSR/ToCSC) to perform efficient arithmetic, though it may also be
+// used directly as an operand to CSR.Mul/CSR.Add.
+type COO struct {
+	r, c     int
+	rows     []int
+	cols     []int
+	data     []float64
+	symmetry Symmetry
+
+	// atOrder and atRowStart cache a row-major permutation of the stored
+	// triplets (see rowMajorOrder) plus the offset into it where each row
+	// begins, so repeated At calls - e.g. from the generic mat.Matrix *
+	// mat.Matrix path in CSR.Mul - binary search within a row instead of
+	// rescanning every triplet. Append invalidates the cache.
+	atOrder    []int
+	atRowStart []int
+}
+
+// NewCOO creates a new COO matrix of size rows x cols with no non-zero
+// elements.  Use Reserve to pre-allocate storage if the number of non-zero
+// elements is known ahead of time.
+func NewCOO(rows, cols int) *COO {
+	return &COO{r: rows, c: cols}
+}
+
+// Dims returns the size of the matrix as the number of rows and columns.
+func (c *COO) Dims() (int, int) {
+	return c.r, c.c
+}
+
+// SetSymmetry flags the receiver as storing only one triangle of a
+// symmetric (or skew-symmetric) matrix. The tag is carried through by
+// ToCSR/ToCSC onto the resulting CSR/CSC.
+func (c *COO) SetSymmetry(s Symmetry) {
+	c.symmetry = s
+}
+
+// Symmetry returns the storage mode previously set by SetSymmetry, or
+// Full if it was never called.
+func (c *COO) Symmetry() Symmetry {
+	return c.symmetry
+}
+
+// NNZ returns the number of stored (row, column, value) triplets.  Duplicate
+// entries are counted separately here - they are only coalesced when the
+// COO is converted to CSR/CSC.
+func (c *COO) NNZ() int {
+	return len(c.data)
+}
+
+// Reserve ensures the COO has capacity for at least nnz triplets without
+// further allocation.
+func (c *COO) Reserve(nnz int) {
+	if cap(c.data) >= nnz {
+		return
+	}
+	rows := make([]int, len(c.rows), nnz)
+	cols := make([]int, len(c.cols), nnz)
+	data := make([]float64, len(c.data), nnz)
+	copy(rows, c.rows)
+	copy(cols, c.cols)
+	copy(data, c.data)
+	c.rows, c.cols, c.data = rows, cols, data
+}
+
+// Append adds a new (i, j, v) triplet to the matrix.  Entries are not
+// merged at insertion time - if (i, j) is appended more than once the
+// duplicates are summed when the COO is converted via ToCSR/ToCSC.
+func (c *COO) Append(i, j int, v float64) {
+	if uint(i) >= uint(c.r) {
+		panic(mat.ErrRowAccess)
+	}
+	if uint(j) >= uint(c.c) {
+		panic(mat.ErrColAccess)
+	}
+	c.rows = append(c.rows, i)
+	c.cols = append(c.cols, j)
+	c.data = append(c.data, v)
+	c.atOrder, c.atRowStart = nil, nil
+}
+
+// At returns the value of the matrix element at (i, j), summing any
+// duplicate triplets stored for that position. The lookup is backed by a
+// cached row-major ordering of the triplets (see ensureAtCache) rather
+// than a linear scan, so repeated calls - as the generic mat.Matrix *
+// mat.Matrix path in CSR.Mul makes - stay cheap.
+func (c *COO) At(i, j int) float64 {
+	if uint(i) >= uint(c.r) {
+		panic(mat.ErrRowAccess)
+	}
+	if uint(j) >= uint(c.c) {
+		panic(mat.ErrColAccess)
+	}
+
+	c.ensureAtCache()
+	start, end := c.atRowStart[i], c.atRowStart[i+1]
+	lo := start + sort.Search(end-start, func(k int) bool {
+		return c.cols[c.atOrder[start+k]] >= j
+	})
+
+	var v float64
+	for lo < end && c.cols[c.atOrder[lo]] == j {
+		v += c.data[c.atOrder[lo]]
+		lo++
+	}
+	return v
+}
+
+// ensureAtCache (re)builds atOrder/atRowStart from the current triplets if
+// they were invalidated by a prior Append, so At can binary search a row
+// instead of scanning every stored triplet.
+func (c *COO) ensureAtCache() {
+	if c.atOrder != nil {
+		return
+	}
+
+	order := c.rowMajorOrder()
+	rowStart := make([]int, c.r+1)
+	row := 0
+	for k := 0; k < len(order); k++ {
+		for row < c.rows[order[k]] {
+			row++
+			rowStart[row] = k
+		}
+	}
+	for row < c.r {
+		row++
+		rowStart[row] = len(order)
+	}
+
+	c.atOrder, c.atRowStart = order, rowStart
+}
+
+// T returns the transpose of the matrix.
+func (c *COO) T() mat.Matrix {
+	return mat.Transpose{Matrix: c}
+}
+
+// DoNonZero calls fn for each coalesced non-zero element of the matrix in
+// row-major order.
+func (c *COO) DoNonZero(fn func(i, j int, v float64)) {
+	order := c.rowMajorOrder()
+	for k := 0; k < len(order); {
+		i, j := c.rows[order[k]], c.cols[order[k]]
+		var v float64
+		for k < len(order) && c.rows[order[k]] == i && c.cols[order[k]] == j {
+			v += c.data[order[k]]
+			k++
+		}
+		fn(i, j, v)
+	}
+}
+
+// isRowSorted reports whether the triplets were appended in row-major
+// order already, allowing conversion to CSR to skip the sort.
+func (c *COO) isRowSorted() bool {
+	for k := 1; k < len(c.rows); k++ {
+		if c.rows[k] < c.rows[k-1] {
+			return false
+		}
+		if c.rows[k] == c.rows[k-1] && c.cols[k] < c.cols[k-1] {
+			return false
+		}
+	}
+	return true
+}
+
+// rowMajorOrder returns a permutation of the stored triplets sorted by
+// (row, column), taking the fast path of returning the identity
+// permutation when the triplets are already in row-major order.
+func (c *COO) rowMajorOrder() []int {
+	order := make([]int, len(c.data))
+	for k := range order {
+		order[k] = k
+	}
+	if c.isRowSorted() {
+		return order
+	}
+	sort.Slice(order, func(a, b int) bool {
+		if c.rows[order[a]] != c.rows[order[b]] {
+			return c.rows[order[a]] < c.rows[order[b]]
+		}
+		return c.cols[order[a]] < c.cols[order[b]]
+	})
+	return order
+}
+
+// ToCSR returns a newly allocated CSR matrix containing a coalesced copy of
+// the elements of the COO, with duplicate (row, column) entries summed
+// together.  If the triplets were appended in row-major order, the
+// expensive sort is skipped.
+func (c *COO) ToCSR() *CSR {
+	order := c.rowMajorOrder()
+
+	indptr := make([]int, c.r+1)
+	ind := make([]int, 0, len(order))
+	data := make([]float64, 0, len(order))
+
+	row := 0
+	for k := 0; k < len(order); {
+		for row < c.rows[order[k]] {
+			row++
+			indptr[row] = len(ind)
+		}
+		col := c.cols[order[k]]
+		var v float64
+		for k < len(order) && c.rows[order[k]] == row && c.cols[order[k]] == col {
+			v += c.data[order[k]]
+			k++
+		}
+		ind = append(ind, col)
+		data = append(data, v)
+	}
+	for row < c.r {
+		row++
+		indptr[row] = len(ind)
+	}
+
+	csr := NewCSR(c.r, c.c, indptr, ind, data)
+	csr.SetSymmetry(c.symmetry)
+	return csr
+}
+
+// ToCSC returns a newly allocated CSC matrix containing a coalesced copy of
+// the elements of the COO, with duplicate (row, column) entries summed
+// together.
+func (c *COO) ToCSC() *CSC {
+	order := make([]int, len(c.data))
+	for k := range order {
+		order[k] = k
+	}
+	sort.Slice(order, func(a, b int) bool {
+		if c.cols[order[a]] != c.cols[order[b]] {
+			return c.cols[order[a]] < c.cols[order[b]]
+		}
+		return c.rows[order[a]] < c.rows[order[b]]
+	})
+
+	indptr := make([]int, c.c+1)
+	ind := make([]int, 0, len(order))
+	data := make([]float64, 0, len(order))
+
+	col := 0
+	for k := 0; k < len(order); {
+		for col < c.cols[order[k]] {
+			col++
+			indptr[col] = len(ind)
+		}
+		row := c.rows[order[k]]
+		var v float64
+		for k < len(order) && c.cols[order[k]] == col && c.rows[order[k]] == row {
+			v += c.data[order[k]]
+			k++
+		}
+		ind = append(ind, row)
+		data = append(data, v)
+	}
+	for col < c.c {
+		col++
+		indptr[col] = len(ind)
+	}
+
+	csc := NewCSC(c.r, c.c, indptr, ind, data)
+	csc.SetSymmetry(c.symmetry)
+	return csc
+}
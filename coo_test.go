@@ -0,0 +1,72 @@
+package sparse
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestCOOToCSRAndToCSCCoalesceDuplicates(t *testing.T) {
+	coo := NewCOO(2, 2)
+	coo.Append(0, 0, 1)
+	coo.Append(0, 0, 2) // duplicate - should sum with the entry above
+	coo.Append(1, 1, 5)
+
+	want := [][]float64{{3, 0}, {0, 5}}
+
+	csr := coo.ToCSR()
+	for i := range want {
+		for j := range want[i] {
+			if got := csr.At(i, j); got != want[i][j] {
+				t.Errorf("ToCSR().At(%d,%d) = %v, want %v", i, j, got, want[i][j])
+			}
+		}
+	}
+
+	csc := coo.ToCSC()
+	for i := range want {
+		for j := range want[i] {
+			if got := csc.At(i, j); got != want[i][j] {
+				t.Errorf("ToCSC().At(%d,%d) = %v, want %v", i, j, got, want[i][j])
+			}
+		}
+	}
+}
+
+func TestCOOAtSumsDuplicatesAndReflectsLaterAppends(t *testing.T) {
+	coo := NewCOO(2, 2)
+	coo.Append(0, 1, 1)
+	coo.Append(0, 1, 2)
+
+	if got := coo.At(0, 1); got != 3 {
+		t.Fatalf("At(0,1) = %v, want 3", got)
+	}
+	// Second call exercises the cached lookup path rather than a rebuild.
+	if got := coo.At(0, 1); got != 3 {
+		t.Fatalf("At(0,1) (cached) = %v, want 3", got)
+	}
+
+	coo.Append(0, 1, 4) // must invalidate the cache built above
+	if got := coo.At(0, 1); got != 7 {
+		t.Fatalf("At(0,1) after Append = %v, want 7", got)
+	}
+}
+
+func TestCOOAppendAndAtPanicWithCorrectAxis(t *testing.T) {
+	coo := NewCOO(2, 3)
+
+	assertPanics := func(name string, want error, fn func()) {
+		t.Helper()
+		defer func() {
+			if r := recover(); r != want {
+				t.Errorf("%s: panic = %v, want %v", name, r, want)
+			}
+		}()
+		fn()
+	}
+
+	assertPanics("Append row", mat.ErrRowAccess, func() { coo.Append(2, 0, 1) })
+	assertPanics("Append col", mat.ErrColAccess, func() { coo.Append(0, 3, 1) })
+	assertPanics("At row", mat.ErrRowAccess, func() { coo.At(2, 0) })
+	assertPanics("At col", mat.ErrColAccess, func() { coo.At(0, 3) })
+}
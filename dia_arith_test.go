@@ -0,0 +1,64 @@
+package sparse
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestMulDIADIA(t *testing.T) {
+	a := NewDIA(3, []float64{1, 2, 3})
+	b := NewDIA(3, []float64{4, 5, 6})
+
+	var c CSR
+	c.Mul(a, b)
+
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			var want float64
+			if i == j {
+				want = a.Diagonal()[i] * b.Diagonal()[i]
+			}
+			if got := c.At(i, j); got != want {
+				t.Errorf("At(%d,%d) = %v, want %v", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestAddDIADIA(t *testing.T) {
+	a := NewDIA(3, []float64{1, 2, 3})
+	b := NewDIA(3, []float64{4, 5, 6})
+
+	var c CSR
+	c.Add(a, b)
+
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			var want float64
+			if i == j {
+				want = a.Diagonal()[i] + b.Diagonal()[i]
+			}
+			if got := c.At(i, j); got != want {
+				t.Errorf("At(%d,%d) = %v, want %v", i, j, got, want)
+			}
+		}
+	}
+}
+
+// TestSubDIADIAWithDifferentLengths checks that Sub panics with
+// mat.ErrShape when the two square DIA operands have different sizes, as
+// it does for any pair of operands whose shapes differ.
+func TestSubDIADIAWithDifferentLengths(t *testing.T) {
+	a := NewDIA(3, []float64{1, 2, 3})
+	b := NewDIA(2, []float64{10, 20})
+
+	defer func() {
+		if r := recover(); r != mat.ErrShape {
+			t.Errorf("Sub panic = %v, want %v", r, mat.ErrShape)
+		}
+	}()
+
+	var c CSR
+	c.Sub(a, b)
+}
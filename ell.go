@@ -0,0 +1,186 @@
+package sparse
+
+import "gonum.org/v1/gonum/mat"
+
+// ELL (ELLPACK) stores a sparse matrix as a fixed-width rows x
+// maxNZPerRow value array with a parallel column-index array, so every
+// row occupies the same amount of storage. Rows with fewer than
+// maxNZPerRow non-zeros are padded with a sentinel column index of -1 and
+// a zero value. This regular, strided layout suits vectorised/unrolled
+// SpMV on hardware where CSR's variable row length gets in the way, at
+// the cost of wasting space proportional to how much row lengths vary -
+// see ToELLOrCSR for the heuristic that falls back to CSR when that waste
+// is too high (the common hybrid ELL+CSR "HYB" decision).
+type ELL struct {
+	r, c, maxNZ int
+	ind         []int
+	data        []float64
+}
+
+// NewELL creates a new ELL matrix of size rows x cols with storage for up
+// to maxRowNNZ non-zero elements per row, all slots initially padded
+// (sentinel column -1, zero value).
+func NewELL(rows, cols, maxRowNNZ int) *ELL {
+	ind := make([]int, rows*maxRowNNZ)
+	for i := range ind {
+		ind[i] = -1
+	}
+	return &ELL{
+		r:     rows,
+		c:     cols,
+		maxNZ: maxRowNNZ,
+		ind:   ind,
+		data:  make([]float64, rows*maxRowNNZ),
+	}
+}
+
+// Dims returns the size of the matrix as the number of rows and columns.
+func (e *ELL) Dims() (int, int) {
+	return e.r, e.c
+}
+
+// NNZ returns the number of stored non-zero elements, i.e. excluding
+// sentinel-padded slots.
+func (e *ELL) NNZ() int {
+	n := 0
+	for _, j := range e.ind {
+		if j != -1 {
+			n++
+		}
+	}
+	return n
+}
+
+// At returns the value of the matrix element at (i, j).
+func (e *ELL) At(i, j int) float64 {
+	if uint(i) >= uint(e.r) {
+		panic(mat.ErrRowAccess)
+	}
+	if uint(j) >= uint(e.c) {
+		panic(mat.ErrColAccess)
+	}
+	base := i * e.maxNZ
+	for k := 0; k < e.maxNZ; k++ {
+		if e.ind[base+k] == j {
+			return e.data[base+k]
+		}
+	}
+	return 0
+}
+
+// T returns the transpose of the matrix.
+func (e *ELL) T() mat.Matrix {
+	return mat.Transpose{Matrix: e}
+}
+
+// DoNonZero calls fn for each non-zero element of the matrix, in
+// row-major order.
+func (e *ELL) DoNonZero(fn func(i, j int, v float64)) {
+	for i := 0; i < e.r; i++ {
+		base := i * e.maxNZ
+		for k := 0; k < e.maxNZ; k++ {
+			if j := e.ind[base+k]; j != -1 {
+				fn(i, j, e.data[base+k])
+			}
+		}
+	}
+}
+
+// ToCSR converts the ELL to a newly allocated CSR matrix.
+func (e *ELL) ToCSR() *CSR {
+	indptr := make([]int, e.r+1)
+	ind := make([]int, 0, e.NNZ())
+	data := make([]float64, 0, e.NNZ())
+
+	for i := 0; i < e.r; i++ {
+		base := i * e.maxNZ
+		for k := 0; k < e.maxNZ; k++ {
+			if j := e.ind[base+k]; j != -1 {
+				ind = append(ind, j)
+				data = append(data, e.data[base+k])
+			}
+		}
+		indptr[i+1] = len(ind)
+	}
+
+	return NewCSR(e.r, e.c, indptr, ind, data)
+}
+
+// ToCSC converts the ELL to a newly allocated CSC matrix, routing through
+// an intermediate COO so the column-major sort/coalesce isn't hand-rolled
+// a second time.
+func (e *ELL) ToCSC() *CSC {
+	coo := NewCOO(e.r, e.c)
+	coo.Reserve(e.NNZ())
+	e.DoNonZero(coo.Append)
+	return coo.ToCSC()
+}
+
+// ToELL converts the receiver CSR to an ELL sized to its widest row, i.e.
+// maxRowNNZ = max_i nnz(row_i).
+func (c *CSR) ToELL() *ELL {
+	rows, cols := c.Dims()
+	raw := c.RawMatrix()
+
+	maxNZ := 0
+	for i := 0; i < rows; i++ {
+		if w := raw.Indptr[i+1] - raw.Indptr[i]; w > maxNZ {
+			maxNZ = w
+		}
+	}
+
+	e := NewELL(rows, cols, maxNZ)
+	for i := 0; i < rows; i++ {
+		base := i * maxNZ
+		begin, end := raw.Indptr[i], raw.Indptr[i+1]
+		for k := begin; k < end; k++ {
+			e.ind[base+k-begin] = raw.Ind[k]
+			e.data[base+k-begin] = raw.Data[k]
+		}
+	}
+	return e
+}
+
+// ToELLOrCSR converts the receiver CSR to an ELL, unless doing so would
+// leave more than threshold (a fraction in [0, 1]) of the resulting ELL's
+// storage as unused padding, in which case it returns the receiver
+// unchanged. This is the common hybrid ELL+CSR ("HYB") decision for
+// matrices whose row lengths vary too widely for a fixed width to pay off
+// (e.g. power-law graphs).
+func (c *CSR) ToELLOrCSR(threshold float64) mat.Matrix {
+	rows, _ := c.Dims()
+	ell := c.ToELL()
+
+	total := rows * ell.maxNZ
+	if total == 0 {
+		return ell
+	}
+	wasted := float64(total-c.NNZ()) / float64(total)
+	if wasted > threshold {
+		return c
+	}
+	return ell
+}
+
+// MulVecELL computes the matrix-vector product lhs*rhs and stores the
+// result in out. Every row walks exactly maxNZPerRow slots regardless of
+// its true sparsity, a tight, branch-light loop suited to unrolling/SIMD.
+func MulVecELL(lhs *ELL, rhs []float64, out []float64) {
+	if len(rhs) != lhs.c {
+		panic(mat.ErrShape)
+	}
+	if len(out) != lhs.r {
+		panic(mat.ErrShape)
+	}
+
+	for i := 0; i < lhs.r; i++ {
+		base := i * lhs.maxNZ
+		var sum float64
+		for k := 0; k < lhs.maxNZ; k++ {
+			if j := lhs.ind[base+k]; j != -1 {
+				sum += lhs.data[base+k] * rhs[j]
+			}
+		}
+		out[i] = sum
+	}
+}
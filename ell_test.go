@@ -0,0 +1,63 @@
+package sparse
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestELLAtAndConversions(t *testing.T) {
+	e := NewELL(2, 3, 2)
+	e.ind[0], e.data[0] = 0, 1 // (0,0) = 1
+	e.ind[1], e.data[1] = 2, 2 // (0,2) = 2
+	e.ind[2], e.data[2] = 1, 3 // (1,1) = 3
+	// ind[3] stays the sentinel -1, leaving row 1's second slot padded.
+
+	want := [][]float64{{1, 0, 2}, {0, 3, 0}}
+	for i := range want {
+		for j := range want[i] {
+			if got := e.At(i, j); got != want[i][j] {
+				t.Errorf("At(%d,%d) = %v, want %v", i, j, got, want[i][j])
+			}
+		}
+	}
+
+	if n := e.NNZ(); n != 3 {
+		t.Errorf("NNZ() = %d, want 3", n)
+	}
+
+	csr := e.ToCSR()
+	for i := range want {
+		for j := range want[i] {
+			if got := csr.At(i, j); got != want[i][j] {
+				t.Errorf("ToCSR().At(%d,%d) = %v, want %v", i, j, got, want[i][j])
+			}
+		}
+	}
+
+	csc := e.ToCSC()
+	for i := range want {
+		for j := range want[i] {
+			if got := csc.At(i, j); got != want[i][j] {
+				t.Errorf("ToCSC().At(%d,%d) = %v, want %v", i, j, got, want[i][j])
+			}
+		}
+	}
+}
+
+func TestELLAtPanicsWithCorrectAxis(t *testing.T) {
+	e := NewELL(2, 3, 1)
+
+	assertPanics := func(name string, want error, fn func()) {
+		t.Helper()
+		defer func() {
+			if r := recover(); r != want {
+				t.Errorf("%s: panic = %v, want %v", name, r, want)
+			}
+		}()
+		fn()
+	}
+
+	assertPanics("row", mat.ErrRowAccess, func() { e.At(2, 0) })
+	assertPanics("col", mat.ErrColAccess, func() { e.At(0, 3) })
+}
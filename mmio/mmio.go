@@ -0,0 +1,317 @@
+// Package mmio provides a reader and writer for the NIST Matrix Market
+// exchange format, allowing matrices from this module to interoperate with
+// the standard sparse benchmark corpus (SuiteSparse, Matrix Market
+// collections).
+package mmio
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/james-bowman/sparse"
+	"gonum.org/v1/gonum/mat"
+)
+
+// banner describes the parsed header line of a Matrix Market file, e.g.
+// "%%MatrixMarket matrix coordinate real general".
+type banner struct {
+	format   string // "coordinate" or "array"
+	field    string // "real", "integer", "pattern" or "complex"
+	symmetry string // "general", "symmetric", "skew-symmetric" or "hermitian"
+}
+
+func parseBanner(line string) (banner, error) {
+	fields := strings.Fields(line)
+	if len(fields) != 5 || fields[0] != "%%MatrixMarket" || strings.ToLower(fields[1]) != "matrix" {
+		return banner{}, fmt.Errorf("mmio: invalid Matrix Market banner %q", line)
+	}
+	return banner{
+		format:   strings.ToLower(fields[2]),
+		field:    strings.ToLower(fields[3]),
+		symmetry: strings.ToLower(fields[4]),
+	}, nil
+}
+
+// ReadMM reads a matrix encoded in the Matrix Market exchange format from r.
+// A `coordinate` banner yields a *sparse.COO; for `symmetric`/
+// `skew-symmetric`/`hermitian` banners (Hermitian values are reduced to
+// their real component, as every matrix type in this module is
+// real-valued) the entries are read as the stored lower triangle and the
+// COO is tagged with the matching sparse.Symmetry rather than being
+// physically expanded, so a consumer that understands symmetric storage
+// (e.g. CSR.MulMatRawVec, or CSR.Mul/Add which expand it automatically
+// when the receiver is Full) never pays for the mirrored half. An `array`
+// banner yields a *mat.Dense with the mirrored entries filled in
+// directly, since mat.Dense has no equivalent storage-mode concept.
+func ReadMM(r io.Reader) (mat.Matrix, error) {
+	s := bufio.NewScanner(r)
+	s.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	if !s.Scan() {
+		return nil, fmt.Errorf("mmio: empty input")
+	}
+	b, err := parseBanner(s.Text())
+	if err != nil {
+		return nil, err
+	}
+
+	dims, err := readDims(s)
+	if err != nil {
+		return nil, err
+	}
+
+	switch b.format {
+	case "coordinate":
+		return readCoordinate(s, b, dims)
+	case "array":
+		return readArray(s, b, dims)
+	default:
+		return nil, fmt.Errorf("mmio: unsupported format %q", b.format)
+	}
+}
+
+// readDims skips blank lines and `%` comments and returns the integers on
+// the first size line (rows, cols[, nnz]).
+func readDims(s *bufio.Scanner) ([]int, error) {
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "%") {
+			continue
+		}
+		fields := strings.Fields(line)
+		dims := make([]int, len(fields))
+		for i, f := range fields {
+			n, err := strconv.Atoi(f)
+			if err != nil {
+				return nil, fmt.Errorf("mmio: invalid size line %q: %w", line, err)
+			}
+			dims[i] = n
+		}
+		return dims, nil
+	}
+	return nil, fmt.Errorf("mmio: missing size line")
+}
+
+func readCoordinate(s *bufio.Scanner, b banner, dims []int) (mat.Matrix, error) {
+	if len(dims) != 3 {
+		return nil, fmt.Errorf("mmio: expected \"rows cols nnz\", got %v", dims)
+	}
+	rows, cols, nnz := dims[0], dims[1], dims[2]
+
+	m := sparse.NewCOO(rows, cols)
+	m.Reserve(nnz)
+	switch b.symmetry {
+	case "symmetric", "hermitian":
+		m.SetSymmetry(sparse.LowerSymmetric)
+	case "skew-symmetric":
+		m.SetSymmetry(sparse.SkewSymmetric)
+	}
+
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "%") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("mmio: invalid entry %q", line)
+		}
+		i, err := strconv.Atoi(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("mmio: invalid row index %q: %w", fields[0], err)
+		}
+		j, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("mmio: invalid column index %q: %w", fields[1], err)
+		}
+
+		v := 1.0
+		if b.field != "pattern" {
+			if len(fields) < 3 {
+				return nil, fmt.Errorf("mmio: entry %q missing value", line)
+			}
+			v, err = strconv.ParseFloat(fields[2], 64)
+			if err != nil {
+				return nil, fmt.Errorf("mmio: invalid value %q: %w", fields[2], err)
+			}
+		}
+
+		// Matrix Market indices are 1-based.
+		i--
+		j--
+		m.Append(i, j, v)
+	}
+
+	return m, s.Err()
+}
+
+func readArray(s *bufio.Scanner, b banner, dims []int) (mat.Matrix, error) {
+	if len(dims) != 2 {
+		return nil, fmt.Errorf("mmio: expected \"rows cols\", got %v", dims)
+	}
+	rows, cols := dims[0], dims[1]
+	d := mat.NewDense(rows, cols, nil)
+
+	// The `array` format lists entries in column-major order. For a
+	// symmetric/skew-symmetric banner only the lower triangle (including
+	// the diagonal) is listed, so column j starts at row j with rows-j
+	// values rather than the full rows values a general banner has.
+	symmetric := b.symmetry != "general"
+	row, col := 0, 0
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "%") {
+			continue
+		}
+		v, err := strconv.ParseFloat(strings.Fields(line)[0], 64)
+		if err != nil {
+			return nil, fmt.Errorf("mmio: invalid value %q: %w", line, err)
+		}
+		d.Set(row, col, v)
+		if symmetric && row != col {
+			if b.symmetry == "skew-symmetric" {
+				d.Set(col, row, -v)
+			} else {
+				d.Set(col, row, v)
+			}
+		}
+		row++
+		if row == rows {
+			col++
+			if symmetric {
+				row = col
+			} else {
+				row = 0
+			}
+		}
+	}
+
+	return d, s.Err()
+}
+
+// sparser is the subset of Sparser this package relies on to walk
+// non-zero elements without pulling in a hard dependency on its
+// definition.
+type sparser interface {
+	mat.Matrix
+	NNZ() int
+	DoNonZero(func(i, j int, v float64))
+}
+
+// symmetric is implemented by any CSR/CSC/COO carrying a storage-mode
+// tag, letting WriteMM emit the matching banner instead of always writing
+// `general`.
+type symmetric interface {
+	Symmetry() sparse.Symmetry
+}
+
+// WriteMM writes a to w in the Matrix Market exchange format, choosing the
+// most compact banner the concrete type allows: `coordinate pattern` for a
+// sparse matrix whose stored values are all 1, `coordinate real` for any
+// other CSR/CSC/COO/DIA, and `array real` for a dense matrix.
+func WriteMM(w io.Writer, a mat.Matrix) error {
+	if dia, ok := a.(*sparse.DIA); ok {
+		return writeDiagonal(w, dia)
+	}
+	if sp, ok := a.(sparser); ok {
+		return writeCoordinate(w, sp)
+	}
+	return writeArray(w, a)
+}
+
+func isAllOnes(sp sparser) bool {
+	allOnes := true
+	sp.DoNonZero(func(i, j int, v float64) {
+		if v != 1 {
+			allOnes = false
+		}
+	})
+	return allOnes
+}
+
+func writeCoordinate(w io.Writer, sp sparser) error {
+	rows, cols := sp.Dims()
+	field := "real"
+	if isAllOnes(sp) {
+		field = "pattern"
+	}
+	symmetry := "general"
+	if sg, ok := sp.(symmetric); ok {
+		symmetry = sg.Symmetry().String()
+	}
+
+	if _, err := fmt.Fprintf(w, "%%%%MatrixMarket matrix coordinate %s %s\n", field, symmetry); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "%d %d %d\n", rows, cols, sp.NNZ()); err != nil {
+		return err
+	}
+
+	var werr error
+	sp.DoNonZero(func(i, j int, v float64) {
+		if werr != nil {
+			return
+		}
+		if field == "pattern" {
+			_, werr = fmt.Fprintf(w, "%d %d\n", i+1, j+1)
+		} else {
+			_, werr = fmt.Fprintf(w, "%d %d %.17g\n", i+1, j+1, v)
+		}
+	})
+	return werr
+}
+
+func writeDiagonal(w io.Writer, dia *sparse.DIA) error {
+	rows, cols := dia.Dims()
+	diagonal := dia.Diagonal()
+
+	field := "pattern"
+	for _, v := range diagonal {
+		if v != 1 {
+			field = "real"
+			break
+		}
+	}
+
+	if _, err := fmt.Fprintf(w, "%%%%MatrixMarket matrix coordinate %s general\n", field); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "%d %d %d\n", rows, cols, len(diagonal)); err != nil {
+		return err
+	}
+	for i, v := range diagonal {
+		var err error
+		if field == "pattern" {
+			_, err = fmt.Fprintf(w, "%d %d\n", i+1, i+1)
+		} else {
+			_, err = fmt.Fprintf(w, "%d %d %.17g\n", i+1, i+1, v)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeArray(w io.Writer, a mat.Matrix) error {
+	rows, cols := a.Dims()
+
+	if _, err := fmt.Fprintf(w, "%%%%MatrixMarket matrix array real general\n"); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "%d %d\n", rows, cols); err != nil {
+		return err
+	}
+
+	for j := 0; j < cols; j++ {
+		for i := 0; i < rows; i++ {
+			if _, err := fmt.Fprintf(w, "%.17g\n", a.At(i, j)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
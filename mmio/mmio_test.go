@@ -0,0 +1,121 @@
+package mmio
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/james-bowman/sparse"
+)
+
+func TestReadWriteMMCoordinateRoundTrip(t *testing.T) {
+	coo := sparse.NewCOO(2, 3)
+	coo.Append(0, 0, 1)
+	coo.Append(0, 2, 2)
+	coo.Append(1, 1, 3)
+
+	var buf bytes.Buffer
+	if err := WriteMM(&buf, coo); err != nil {
+		t.Fatalf("WriteMM: %v", err)
+	}
+
+	got, err := ReadMM(&buf)
+	if err != nil {
+		t.Fatalf("ReadMM: %v", err)
+	}
+
+	rows, cols := got.Dims()
+	if rows != 2 || cols != 3 {
+		t.Fatalf("Dims() = (%d, %d), want (2, 3)", rows, cols)
+	}
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			if want, have := coo.At(i, j), got.At(i, j); want != have {
+				t.Errorf("At(%d,%d) = %v, want %v", i, j, have, want)
+			}
+		}
+	}
+}
+
+func TestReadMMCoordinatePattern(t *testing.T) {
+	const mm = `%%MatrixMarket matrix coordinate pattern general
+2 2 2
+1 1
+2 2
+`
+	got, err := ReadMM(strings.NewReader(mm))
+	if err != nil {
+		t.Fatalf("ReadMM: %v", err)
+	}
+
+	want := [][]float64{{1, 0}, {0, 1}}
+	for i := range want {
+		for j := range want[i] {
+			if have := got.At(i, j); have != want[i][j] {
+				t.Errorf("At(%d,%d) = %v, want %v", i, j, have, want[i][j])
+			}
+		}
+	}
+}
+
+// TestReadMMSymmetricArrayReadsLowerTriangleOnly exercises the exact case a
+// prior bug fix addressed: an `array real symmetric` banner lists only the
+// lower triangle (including the diagonal), column by column, so column j
+// has rows-j values rather than every row.
+func TestReadMMSymmetricArrayReadsLowerTriangleOnly(t *testing.T) {
+	const mm = `%%MatrixMarket matrix array real symmetric
+3 3
+1
+2
+3
+4
+5
+6
+`
+	got, err := ReadMM(strings.NewReader(mm))
+	if err != nil {
+		t.Fatalf("ReadMM: %v", err)
+	}
+
+	want := [][]float64{
+		{1, 2, 3},
+		{2, 4, 5},
+		{3, 5, 6},
+	}
+	for i := range want {
+		for j := range want[i] {
+			if have := got.At(i, j); have != want[i][j] {
+				t.Errorf("At(%d,%d) = %v, want %v", i, j, have, want[i][j])
+			}
+		}
+	}
+}
+
+func TestReadMMSkewSymmetricArray(t *testing.T) {
+	const mm = `%%MatrixMarket matrix array real skew-symmetric
+3 3
+0
+2
+3
+0
+5
+0
+`
+	got, err := ReadMM(strings.NewReader(mm))
+	if err != nil {
+		t.Fatalf("ReadMM: %v", err)
+	}
+
+	want := [][]float64{
+		{0, -2, -3},
+		{2, 0, -5},
+		{3, 5, 0},
+	}
+	for i := range want {
+		for j := range want[i] {
+			if have := got.At(i, j); have != want[i][j] {
+				t.Errorf("At(%d,%d) = %v, want %v", i, j, have, want[i][j])
+			}
+		}
+	}
+}
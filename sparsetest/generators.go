@@ -0,0 +1,178 @@
+// Package sparsetest provides gopter generators and canned properties for
+// testing arithmetic invariants of this module's sparse matrix types,
+// following the approach nalgebra takes to property-testing its own sparse
+// matrices. The properties compare sparse results with each other, or
+// with an input matrix, to within a tolerance; none of them compares
+// against a mat.Dense oracle.
+package sparsetest
+
+import (
+	"math/rand"
+
+	"github.com/james-bowman/sparse"
+	"github.com/leanovate/gopter"
+	"gonum.org/v1/gonum/mat"
+)
+
+// degenerateShape picks a rows x cols shape, occasionally forcing one of
+// the degenerate cases (0 rows, 0 cols, both 0, at most one row, at most
+// one column) that a purely random draw would rarely hit.
+func degenerateShape(rng *rand.Rand, maxRows, maxCols int) (rows, cols int) {
+	rows = rng.Intn(maxRows + 1)
+	cols = rng.Intn(maxCols + 1)
+	switch rng.Intn(8) {
+	case 0:
+		rows = 0
+	case 1:
+		cols = 0
+	case 2:
+		rows, cols = 0, 0
+	case 3:
+		rows = min(rows, 1)
+	case 4:
+		cols = min(cols, 1)
+	}
+	return rows, cols
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// randomDense builds a rows x cols dense matrix whose entries are
+// independently non-zero with probability density (a Bernoulli mask over
+// the dense shape).
+func randomDense(rng *rand.Rand, rows, cols int, density float64) *mat.Dense {
+	data := make([]float64, rows*cols)
+	for i := range data {
+		if rng.Float64() < density {
+			if v := rng.NormFloat64(); v != 0 {
+				data[i] = v
+			} else {
+				data[i] = 1
+			}
+		}
+	}
+	if rows == 0 || cols == 0 {
+		return mat.NewDense(rows, cols, nil)
+	}
+	return mat.NewDense(rows, cols, data)
+}
+
+// denseToCOO copies the non-zero elements of d into a freshly built COO
+// of the same shape.
+func denseToCOO(d *mat.Dense) *sparse.COO {
+	rows, cols := d.Dims()
+	coo := sparse.NewCOO(rows, cols)
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			if v := d.At(i, j); v != 0 {
+				coo.Append(i, j, v)
+			}
+		}
+	}
+	return coo
+}
+
+// DenseGen generates a dense rows x cols oracle matrix, covering
+// degenerate shapes per degenerateShape.
+func DenseGen(maxRows, maxCols int, density float64) gopter.Gen {
+	return func(params *gopter.GenParameters) *gopter.GenResult {
+		rows, cols := degenerateShape(params.Rng, maxRows, maxCols)
+		d := randomDense(params.Rng, rows, cols, density)
+		return gopter.NewGenResult(d, gopter.NoShrinker)
+	}
+}
+
+// CSRGen generates a random *sparse.CSR of up to maxRows x maxCols, with
+// each element independently non-zero with probability density.
+func CSRGen(maxRows, maxCols int, density float64) gopter.Gen {
+	return DenseGen(maxRows, maxCols, density).Map(func(d *mat.Dense) *sparse.CSR {
+		return denseToCOO(d).ToCSR()
+	})
+}
+
+// CSCGen generates a random *sparse.CSC of up to maxRows x maxCols, with
+// each element independently non-zero with probability density.
+func CSCGen(maxRows, maxCols int, density float64) gopter.Gen {
+	return DenseGen(maxRows, maxCols, density).Map(func(d *mat.Dense) *sparse.CSC {
+		return denseToCOO(d).ToCSC()
+	})
+}
+
+// COOGen generates a random *sparse.COO of up to maxRows x maxCols, with
+// each element independently non-zero with probability density.
+func COOGen(maxRows, maxCols int, density float64) gopter.Gen {
+	return DenseGen(maxRows, maxCols, density).Map(denseToCOO)
+}
+
+// DIAGen generates a random square *sparse.DIA of up to maxN x maxN, with
+// each diagonal element independently non-zero with probability density.
+func DIAGen(maxN int, density float64) gopter.Gen {
+	return func(params *gopter.GenParameters) *gopter.GenResult {
+		n := params.Rng.Intn(maxN + 1)
+		diagonal := make([]float64, n)
+		for i := range diagonal {
+			if params.Rng.Float64() < density {
+				if v := params.Rng.NormFloat64(); v != 0 {
+					diagonal[i] = v
+				} else {
+					diagonal[i] = 1
+				}
+			}
+		}
+		return gopter.NewGenResult(sparse.NewDIA(n, diagonal), gopter.NoShrinker)
+	}
+}
+
+// RowGen generates a []float64 of up to maxLen elements, with each
+// element independently non-zero with probability density - used to
+// exercise SPA scatter/gather directly.
+func RowGen(maxLen int, density float64) gopter.Gen {
+	return func(params *gopter.GenParameters) *gopter.GenResult {
+		n := params.Rng.Intn(maxLen + 1)
+		row := make([]float64, n)
+		for i := range row {
+			if params.Rng.Float64() < density {
+				if v := params.Rng.NormFloat64(); v != 0 {
+					row[i] = v
+				} else {
+					row[i] = 1
+				}
+			}
+		}
+		return gopter.NewGenResult(row, gopter.NoShrinker)
+	}
+}
+
+// CSRPairGen generates two independent square *sparse.CSR matrices of the
+// same randomly chosen size, for properties (e.g. AddIsCommutative) that
+// need two compatible operands.
+func CSRPairGen(maxN int, density float64) gopter.Gen {
+	return func(params *gopter.GenParameters) *gopter.GenResult {
+		n := params.Rng.Intn(maxN + 1)
+		pair := [2]*sparse.CSR{
+			denseToCOO(randomDense(params.Rng, n, n, density)).ToCSR(),
+			denseToCOO(randomDense(params.Rng, n, n, density)).ToCSR(),
+		}
+		return gopter.NewGenResult(pair, gopter.NoShrinker)
+	}
+}
+
+// CSRTripleGen generates three independent square *sparse.CSR matrices of
+// the same randomly chosen size, for properties (e.g. MulIsAssociative)
+// that need three compatible operands.
+func CSRTripleGen(maxN int, density float64) gopter.Gen {
+	return func(params *gopter.GenParameters) *gopter.GenResult {
+		n := params.Rng.Intn(maxN + 1)
+		triple := [3]*sparse.CSR{
+			denseToCOO(randomDense(params.Rng, n, n, density)).ToCSR(),
+			denseToCOO(randomDense(params.Rng, n, n, density)).ToCSR(),
+			denseToCOO(randomDense(params.Rng, n, n, density)).ToCSR(),
+		}
+		return gopter.NewGenResult(triple, gopter.NoShrinker)
+	}
+}
@@ -0,0 +1,173 @@
+package sparsetest
+
+import (
+	"github.com/james-bowman/sparse"
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/prop"
+	"gonum.org/v1/gonum/mat"
+)
+
+// tolerance is the absolute error allowed when comparing two sparse
+// arithmetic results that should be equal, to absorb the floating
+// point rounding that reordering additions/multiplications introduces.
+const tolerance = 1e-9
+
+// AddIsCommutative asserts that a.Add(b) == b.Add(a) for every pair
+// produced by pairs (expected to be a CSRPairGen), comparing the two CSR
+// results with each other; no mat.Dense oracle is computed.
+func AddIsCommutative(pairs gopter.Gen) gopter.Prop {
+	return prop.ForAll(
+		func(p [2]*sparse.CSR) bool {
+			a, b := p[0], p[1]
+
+			var ab, ba sparse.CSR
+			ab.Add(a, b)
+			ba.Add(b, a)
+
+			return mat.EqualApprox(&ab, &ba, tolerance)
+		},
+		pairs,
+	)
+}
+
+// MulIsAssociative asserts that (a*b)*c == a*(b*c) for every triple
+// produced by triples (expected to be a CSRTripleGen).
+func MulIsAssociative(triples gopter.Gen) gopter.Prop {
+	return prop.ForAll(
+		func(t [3]*sparse.CSR) bool {
+			a, b, c := t[0], t[1], t[2]
+
+			var ab, abThenC sparse.CSR
+			ab.Mul(a, b)
+			abThenC.Mul(&ab, c)
+
+			var bc, aThenBC sparse.CSR
+			bc.Mul(b, c)
+			aThenBC.Mul(a, &bc)
+
+			return mat.EqualApprox(&abThenC, &aThenBC, tolerance)
+		},
+		triples,
+	)
+}
+
+// MulByIdentity asserts that a*I == a == I*a for every square matrix
+// produced by squares (expected to be a CSRGen constructed with equal
+// maxRows/maxCols).
+func MulByIdentity(squares gopter.Gen) gopter.Prop {
+	return prop.ForAll(
+		func(a *sparse.CSR) bool {
+			n, m := a.Dims()
+			if n != m {
+				return true // not square - nothing to assert
+			}
+
+			identity := sparse.NewDIA(n, ones(n))
+
+			var left, right sparse.CSR
+			left.Mul(a, identity)
+			right.Mul(identity, a)
+
+			return mat.EqualApprox(a, &left, tolerance) && mat.EqualApprox(a, &right, tolerance)
+		},
+		squares,
+	)
+}
+
+// AddAliasedReceiverMatchesUnaliased asserts that a.Add(a, b) produces the
+// same result as adding into a fresh receiver, exercising the
+// aliased-receiver path through CSR.spalloc/temporaryWorkspace that a
+// fresh zero-value receiver never takes.
+func AddAliasedReceiverMatchesUnaliased(pairs gopter.Gen) gopter.Prop {
+	return prop.ForAll(
+		func(p [2]*sparse.CSR) bool {
+			a, b := p[0], p[1]
+
+			var want sparse.CSR
+			want.Add(a, b)
+
+			a.Add(a, b)
+
+			return mat.EqualApprox(a, &want, tolerance)
+		},
+		pairs,
+	)
+}
+
+// MulAliasedReceiverMatchesUnaliased asserts that a.Mul(a, a) produces the
+// same result as multiplying into a fresh receiver, exercising the
+// aliased-receiver path through CSR.spalloc/temporaryWorkspace for Mul
+// where the receiver aliases both operands at once.
+func MulAliasedReceiverMatchesUnaliased(squares gopter.Gen) gopter.Prop {
+	return prop.ForAll(
+		func(a *sparse.CSR) bool {
+			n, m := a.Dims()
+			if n != m {
+				return true // not square - nothing to assert
+			}
+
+			var want sparse.CSR
+			want.Mul(a, a)
+
+			a.Mul(a, a)
+
+			return mat.EqualApprox(a, &want, tolerance)
+		},
+		squares,
+	)
+}
+
+func ones(n int) []float64 {
+	v := make([]float64, n)
+	for i := range v {
+		v[i] = 1
+	}
+	return v
+}
+
+// TransposeInvolutive asserts that (a^T)^T == a for every matrix produced
+// by matrices (expected to be a CSRGen/CSCGen/COOGen/DIAGen).
+func TransposeInvolutive(matrices gopter.Gen) gopter.Prop {
+	return prop.ForAll(
+		func(a mat.Matrix) bool {
+			tt := a.T().T()
+			return mat.EqualApprox(a, tt, tolerance)
+		},
+		matrices,
+	)
+}
+
+// SPAScatterGatherRoundTrip asserts that scattering a dense row through
+// an SPA and gathering it straight back out reproduces the original row
+// exactly (up to tolerance) - the invariant every CSR arithmetic method
+// in this module depends on for row-by-row accumulation.
+func SPAScatterGatherRoundTrip(rows gopter.Gen) gopter.Prop {
+	return prop.ForAll(
+		func(row []float64) bool {
+			spa := sparse.NewSPA(len(row))
+
+			var ind []int
+			for i, v := range row {
+				if v != 0 {
+					spa.ScatterValue(v, i, 1, &ind)
+				}
+			}
+
+			var data []float64
+			spa.GatherAndZero(&data, &ind)
+
+			got := make([]float64, len(row))
+			for k, i := range ind {
+				got[i] += data[k]
+			}
+
+			for i, want := range row {
+				if diff := got[i] - want; diff > tolerance || diff < -tolerance {
+					return false
+				}
+			}
+			return true
+		},
+		rows,
+	)
+}
@@ -0,0 +1,25 @@
+package sparsetest
+
+import (
+	"testing"
+
+	"github.com/leanovate/gopter"
+)
+
+func TestCSRArithmeticProperties(t *testing.T) {
+	parameters := gopter.DefaultTestParameters()
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("addition is commutative", AddIsCommutative(CSRPairGen(8, 0.3)))
+	properties.Property("multiplication is associative", MulIsAssociative(CSRTripleGen(6, 0.3)))
+	properties.Property("multiplying by the identity is a no-op", MulByIdentity(CSRGen(8, 8, 0.3)))
+	properties.Property("transpose is involutive (CSR)", TransposeInvolutive(CSRGen(8, 8, 0.3)))
+	properties.Property("transpose is involutive (CSC)", TransposeInvolutive(CSCGen(8, 8, 0.3)))
+	properties.Property("transpose is involutive (COO)", TransposeInvolutive(COOGen(8, 8, 0.3)))
+	properties.Property("transpose is involutive (DIA)", TransposeInvolutive(DIAGen(8, 0.3)))
+	properties.Property("SPA scatter/gather round-trips a row", SPAScatterGatherRoundTrip(RowGen(16, 0.3)))
+	properties.Property("Add with an aliased receiver matches a fresh one", AddAliasedReceiverMatchesUnaliased(CSRPairGen(8, 0.3)))
+	properties.Property("Mul with an aliased receiver matches a fresh one", MulAliasedReceiverMatchesUnaliased(CSRGen(6, 6, 0.3)))
+
+	properties.TestingRun(t)
+}
@@ -0,0 +1,129 @@
+package symbolic
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/james-bowman/sparse"
+	"gonum.org/v1/gonum/mat"
+)
+
+// CholeskyFactor is a numeric Cholesky factorization A = L L^T of a
+// sparse symmetric positive-definite matrix A, stored as a lower
+// triangular CSC with each column's diagonal entry first.
+type CholeskyFactor struct {
+	l *sparse.CSC
+	n int
+}
+
+// NewCholeskyFactor computes the numeric Cholesky factorization of A,
+// reusing the symbolic pattern from SymbolicCholesky and filling it with
+// an up-looking numeric pass (Davis, "Direct Methods for Sparse Linear
+// Systems", Algorithm 4.1): column k of A is gathered into a dense
+// working vector together with the symbolic reach of its sub-diagonal
+// entries through the elimination tree, each previously-computed column i
+// in that reach is used to eliminate row i from the working vector, and
+// the result becomes row k of L (each L(k,i) is appended to column i,
+// and the diagonal L(k,k) starts column k).
+//
+// A must store only the upper triangle (including the diagonal) of the
+// symmetric matrix being factorized, as required by EliminationTree.
+func NewCholeskyFactor(A *sparse.CSC) (*CholeskyFactor, error) {
+	n, _ := A.Dims()
+	parent := EliminationTree(A)
+	l := SymbolicCholesky(A)
+
+	araw := A.RawMatrix()
+	lraw := l.RawMatrix()
+
+	fill := make([]int, n)
+	copy(fill, lraw.Indptr[:n])
+
+	work := make([]float64, n)
+	marked := make([]bool, n)
+	stack := make([]int, n)
+
+	for k := 0; k < n; k++ {
+		top := n
+		marked[k] = true
+
+		for p := araw.Indptr[k]; p < araw.Indptr[k+1]; p++ {
+			i := araw.Ind[p]
+			if i > k {
+				continue
+			}
+			work[i] += araw.Data[p]
+
+			length := 0
+			for j := i; !marked[j]; j = parent[j] {
+				stack[length] = j
+				length++
+				marked[j] = true
+			}
+			for length > 0 {
+				length--
+				top--
+				stack[top] = stack[length]
+			}
+		}
+
+		d := work[k]
+		work[k] = 0
+
+		for p := top; p < n; p++ {
+			i := stack[p]
+			lii := lraw.Data[lraw.Indptr[i]]
+			lki := work[i] / lii
+			work[i] = 0
+			for q := lraw.Indptr[i] + 1; q < fill[i]; q++ {
+				work[lraw.Ind[q]] -= lki * lraw.Data[q]
+			}
+			d -= lki * lki
+
+			lraw.Ind[fill[i]] = k
+			lraw.Data[fill[i]] = lki
+			fill[i]++
+			marked[i] = false
+		}
+
+		if d <= 0 {
+			return nil, fmt.Errorf("symbolic: matrix is not positive definite (failed at column %d)", k)
+		}
+
+		lraw.Ind[fill[k]] = k
+		lraw.Data[fill[k]] = math.Sqrt(d)
+		fill[k]++
+		marked[k] = false
+	}
+
+	return &CholeskyFactor{l: l, n: n}, nil
+}
+
+// Solve returns x satisfying A x = b, via forward substitution (L y = b)
+// followed by backward substitution (L^T x = y).
+func (f *CholeskyFactor) Solve(b []float64) []float64 {
+	if len(b) != f.n {
+		panic(mat.ErrShape)
+	}
+	raw := f.l.RawMatrix()
+	x := make([]float64, f.n)
+	copy(x, b)
+
+	for j := 0; j < f.n; j++ {
+		begin := raw.Indptr[j]
+		x[j] /= raw.Data[begin]
+		for p := begin + 1; p < raw.Indptr[j+1]; p++ {
+			x[raw.Ind[p]] -= raw.Data[p] * x[j]
+		}
+	}
+
+	for j := f.n - 1; j >= 0; j-- {
+		begin := raw.Indptr[j]
+		for p := begin + 1; p < raw.Indptr[j+1]; p++ {
+			x[j] -= raw.Data[p] * x[raw.Ind[p]]
+		}
+		x[j] /= raw.Data[begin]
+	}
+
+	return x
+}
@@ -0,0 +1,261 @@
+// Package symbolic computes the symbolic structures needed to drive a
+// direct sparse solver on top of this module: the elimination tree of a
+// symmetric pattern, the resulting column counts of its Cholesky factor L,
+// and the non-zero pattern of L itself. These are the standard
+// precomputed inputs to a numeric LDL/Cholesky pass (see NewCholeskyFactor
+// in cholesky.go).
+//
+// EliminationTree and ColumnCounts both expect A to store only the upper
+// triangle (including the diagonal) of a symmetric matrix, column by
+// column - i.e. a CSC built with sparse.UpperSymmetric - which is the
+// layout cs_etree/cs_counts operate on in Davis, "Direct Methods for
+// Sparse Linear Systems".
+package symbolic
+
+import (
+	"github.com/james-bowman/sparse"
+)
+
+// EliminationTree computes the elimination tree of the symmetric sparsity
+// pattern stored in the upper triangle of A, following Liu's algorithm
+// ("A Compact Row Storage Scheme for Cholesky Factors", 1986): columns are
+// processed left to right, and for each stored row index i less than the
+// current column k, i is walked up through its ancestor chain -
+// compressing the path as it goes - until an unset parent is found, which
+// is then set to k. parent[k] == -1 marks k as a root of the tree.
+func EliminationTree(A *sparse.CSC) []int {
+	n, _ := A.Dims()
+	raw := A.RawMatrix()
+
+	parent := make([]int, n)
+	ancestor := make([]int, n)
+
+	for k := 0; k < n; k++ {
+		parent[k] = -1
+		ancestor[k] = -1
+		for p := raw.Indptr[k]; p < raw.Indptr[k+1]; p++ {
+			i := raw.Ind[p]
+			for i != -1 && i < k {
+				next := ancestor[i]
+				ancestor[i] = k
+				if next == -1 {
+					parent[i] = k
+				}
+				i = next
+			}
+		}
+	}
+
+	return parent
+}
+
+// Postorder returns a postordering of the elimination tree described by
+// parent: every node appears after all of its descendants.
+func Postorder(parent []int) []int {
+	n := len(parent)
+	head := make([]int, n)
+	next := make([]int, n)
+	for j := range head {
+		head[j] = -1
+	}
+	for j := n - 1; j >= 0; j-- {
+		if parent[j] == -1 {
+			continue
+		}
+		next[j] = head[parent[j]]
+		head[parent[j]] = j
+	}
+
+	post := make([]int, 0, n)
+	stack := make([]int, 0, n)
+	for j := 0; j < n; j++ {
+		if parent[j] != -1 {
+			continue
+		}
+		stack = append(stack, j)
+		for len(stack) > 0 {
+			node := stack[len(stack)-1]
+			child := head[node]
+			if child == -1 {
+				post = append(post, node)
+				stack = stack[:len(stack)-1]
+				continue
+			}
+			head[node] = next[child]
+			stack = append(stack, child)
+		}
+	}
+	return post
+}
+
+// ColumnCounts computes |L(:,j)| for j in [0, n) - the number of non-zeros
+// in each column of the Cholesky factor L of A - given the elimination
+// tree parent returned by EliminationTree. It follows the skeleton-graph
+// algorithm of Gilbert, Ng & Peyton ("An efficient algorithm to compute
+// row and column counts for sparse Cholesky factorization", 1994), using
+// a postorder of the etree, each node's first descendant, and a
+// union-find based least-common-ancestor test to detect when two leaves
+// of the skeleton graph for column j share credit for a row.
+func ColumnCounts(A *sparse.CSC, parent []int) []int {
+	n, _ := A.Dims()
+	post := Postorder(parent)
+	at := transposePattern(A)
+
+	first := make([]int, n)
+	for j := range first {
+		first[j] = -1
+	}
+	delta := make([]int, n)
+	for k, j := range post {
+		if first[j] == -1 {
+			delta[j] = 1
+		}
+		for node := j; node != -1 && first[node] == -1; node = parent[node] {
+			first[node] = k
+		}
+	}
+
+	maxfirst := make([]int, n)
+	prevleaf := make([]int, n)
+	ancestor := make([]int, n)
+	for i := range ancestor {
+		maxfirst[i] = -1
+		prevleaf[i] = -1
+		ancestor[i] = i
+	}
+
+	// leaf implements the jleaf test (cs_leaf in CSparse): it reports
+	// whether A(i,j) is the first, a repeat, or not a leaf of the
+	// skeleton graph's subtree rooted below row i, and if it is a
+	// repeat, the least common ancestor q of the two leaves so the
+	// double-counted contribution can be removed from delta[q].
+	leaf := func(i, j int) (q, jleaf int) {
+		if i <= j || first[j] <= maxfirst[i] {
+			return -1, 0
+		}
+		maxfirst[i] = first[j]
+		jprev := prevleaf[i]
+		prevleaf[i] = j
+		if jprev == -1 {
+			return i, 1
+		}
+		for q = jprev; q != ancestor[q]; q = ancestor[q] {
+		}
+		for s := jprev; s != q; {
+			next := ancestor[s]
+			ancestor[s] = q
+			s = next
+		}
+		return q, 2
+	}
+
+	for _, j := range post {
+		if parent[j] != -1 {
+			delta[parent[j]]--
+		}
+		for _, i := range at[j] {
+			q, jleaf := leaf(i, j)
+			if jleaf >= 1 {
+				delta[j]++
+			}
+			if jleaf == 2 {
+				delta[q]--
+			}
+		}
+		if parent[j] != -1 {
+			ancestor[j] = parent[j]
+		}
+	}
+
+	colcount := delta
+	for _, j := range post {
+		if parent[j] != -1 {
+			colcount[parent[j]] += colcount[j]
+		}
+	}
+	return colcount
+}
+
+// transposePattern returns, for every row i of A, the set of columns that
+// store a non-zero in row i - i.e. the column-oriented pattern of A^T,
+// built in a single pass over A's own column-major storage.
+func transposePattern(A *sparse.CSC) [][]int {
+	n, _ := A.Dims()
+	raw := A.RawMatrix()
+
+	counts := make([]int, n)
+	for _, row := range raw.Ind {
+		counts[row]++
+	}
+	at := make([][]int, n)
+	for i, cnt := range counts {
+		at[i] = make([]int, 0, cnt)
+	}
+	for col := 0; col < n; col++ {
+		for p := raw.Indptr[col]; p < raw.Indptr[col+1]; p++ {
+			row := raw.Ind[p]
+			at[row] = append(at[row], col)
+		}
+	}
+	return at
+}
+
+// SymbolicCholesky computes the non-zero pattern of the Cholesky factor L
+// of A and returns it as a CSC with Lp/Li allocated from ColumnCounts and
+// no numeric values filled in (Data is zeroed). It re-derives each
+// column's row pattern with a second elimination-tree traversal per
+// column of A (Davis, Algorithm 4.1's symbolic-only half), pushing the
+// reach of each row onto a stack so that, combined with the increasing
+// column order of the outer loop, every column ends up with its row
+// indices already sorted.
+func SymbolicCholesky(A *sparse.CSC) *sparse.CSC {
+	n, _ := A.Dims()
+	parent := EliminationTree(A)
+	colcount := ColumnCounts(A, parent)
+
+	lp := make([]int, n+1)
+	for j := 0; j < n; j++ {
+		lp[j+1] = lp[j] + colcount[j]
+	}
+	li := make([]int, lp[n])
+	fill := make([]int, n)
+	copy(fill, lp[:n])
+
+	raw := A.RawMatrix()
+	marked := make([]bool, n)
+	stack := make([]int, n)
+
+	for k := 0; k < n; k++ {
+		top := n
+		marked[k] = true
+		for p := raw.Indptr[k]; p < raw.Indptr[k+1]; p++ {
+			i := raw.Ind[p]
+			if i > k {
+				continue
+			}
+			length := 0
+			for !marked[i] {
+				stack[length] = i
+				length++
+				marked[i] = true
+				i = parent[i]
+			}
+			for length > 0 {
+				length--
+				top--
+				stack[top] = stack[length]
+			}
+		}
+		for p := top; p < n; p++ {
+			row := stack[p]
+			li[fill[row]] = k
+			fill[row]++
+			marked[row] = false
+		}
+		li[fill[k]] = k
+		fill[k]++
+		marked[k] = false
+	}
+
+	return sparse.NewCSC(n, n, lp, li, make([]float64, lp[n]))
+}
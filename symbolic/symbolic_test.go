@@ -0,0 +1,74 @@
+package symbolic
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/james-bowman/sparse"
+	"gonum.org/v1/gonum/mat"
+)
+
+// arrowhead4 builds the upper triangle (including the diagonal) of the
+// classic 4x4 arrowhead matrix
+//
+//	[4 0 0 1]
+//	[0 4 0 1]
+//	[0 0 4 1]
+//	[1 1 1 4]
+//
+// - a textbook no-fill example (Davis, "Direct Methods for Sparse Linear
+// Systems") whose elimination tree is a single star rooted at column 3.
+func arrowhead4() *sparse.CSC {
+	coo := sparse.NewCOO(4, 4)
+	coo.Append(0, 0, 4)
+	coo.Append(0, 3, 1)
+	coo.Append(1, 1, 4)
+	coo.Append(1, 3, 1)
+	coo.Append(2, 2, 4)
+	coo.Append(2, 3, 1)
+	coo.Append(3, 3, 4)
+	return coo.ToCSC()
+}
+
+func TestEliminationTreeAndColumnCounts(t *testing.T) {
+	a := arrowhead4()
+
+	parent := EliminationTree(a)
+	wantParent := []int{3, 3, 3, -1}
+	if !reflect.DeepEqual(parent, wantParent) {
+		t.Fatalf("EliminationTree = %v, want %v", parent, wantParent)
+	}
+
+	colcount := ColumnCounts(a, parent)
+	wantColcount := []int{2, 2, 2, 1}
+	if !reflect.DeepEqual(colcount, wantColcount) {
+		t.Fatalf("ColumnCounts = %v, want %v", colcount, wantColcount)
+	}
+}
+
+func TestCholeskyFactorSolveRoundTrip(t *testing.T) {
+	a := arrowhead4()
+
+	factor, err := NewCholeskyFactor(a)
+	if err != nil {
+		t.Fatalf("NewCholeskyFactor: %v", err)
+	}
+
+	dense := mat.NewDense(4, 4, []float64{
+		4, 0, 0, 1,
+		0, 4, 0, 1,
+		0, 0, 4, 1,
+		1, 1, 1, 4,
+	})
+
+	b := []float64{1, 2, 3, 4}
+	x := factor.Solve(b)
+
+	var got mat.VecDense
+	got.MulVec(dense, mat.NewVecDense(4, x))
+	for i, want := range b {
+		if diff := got.AtVec(i) - want; diff > 1e-9 || diff < -1e-9 {
+			t.Fatalf("A*x[%d] = %v, want %v", i, got.AtVec(i), want)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package sparse
+
+import "gonum.org/v1/gonum/mat"
+
+// Symmetry describes how much of a symmetric matrix is physically stored,
+// mirroring the sparse_full/sparse_lower/sparse_upper storage modes
+// distinguished by the Fortran stdlib sparse spec. CSR and CSC both carry
+// a symmetry tag (defaulting to Full) that MulMatRawVec, Mul and Add
+// consult to decide whether a stored element implies a mirrored one.
+type Symmetry int
+
+const (
+	// Full means every non-zero element of the matrix is stored.
+	Full Symmetry = iota
+
+	// LowerSymmetric means only the lower triangle (including the
+	// diagonal) is stored; element (i, j) for i<j is implied to equal
+	// the stored element (j, i).
+	LowerSymmetric
+
+	// UpperSymmetric means only the upper triangle (including the
+	// diagonal) is stored; element (j, i) for i<j is implied to equal
+	// the stored element (i, j).
+	UpperSymmetric
+
+	// SkewSymmetric means only one triangle is stored and the implied
+	// mirrored element is the negation of the stored one.
+	SkewSymmetric
+)
+
+// String returns the lower-case name used for this storage mode in
+// Matrix Market banners.
+func (s Symmetry) String() string {
+	switch s {
+	case Full:
+		return "general"
+	case LowerSymmetric, UpperSymmetric:
+		return "symmetric"
+	case SkewSymmetric:
+		return "skew-symmetric"
+	default:
+		return "unknown"
+	}
+}
+
+// SetSymmetry flags the receiver as storing only one triangle of a
+// symmetric (or skew-symmetric) matrix, so MulMatRawVec and the
+// arithmetic methods on CSR can exploit the implied mirrored elements.
+func (c *CSR) SetSymmetry(s Symmetry) {
+	c.symmetry = s
+}
+
+// Symmetry returns the storage mode previously set by SetSymmetry, or
+// Full if it was never called.
+func (c *CSR) Symmetry() Symmetry {
+	return c.symmetry
+}
+
+// SetSymmetry flags the receiver as storing only one triangle of a
+// symmetric (or skew-symmetric) matrix.
+func (c *CSC) SetSymmetry(s Symmetry) {
+	c.symmetry = s
+}
+
+// Symmetry returns the storage mode previously set by SetSymmetry, or
+// Full if it was never called.
+func (c *CSC) Symmetry() Symmetry {
+	return c.symmetry
+}
+
+// symmetryOf returns m's symmetry tag if it is a CSR, CSC or COO, or Full
+// for any other mat.Matrix implementation (which by definition stores
+// every element).
+func symmetryOf(m mat.Matrix) Symmetry {
+	switch t := m.(type) {
+	case *CSR:
+		return t.symmetry
+	case *CSC:
+		return t.symmetry
+	case *COO:
+		return t.symmetry
+	default:
+		return Full
+	}
+}
+
+// expandSymmetric materialises a CSR/CSC/COO flagged
+// Lower/UpperSymmetric/SkewSymmetric into an equivalent full CSR or CSC by
+// scattering its stored elements, and their implied mirrors, through a
+// COO - reusing COO's sort/coalesce pass rather than hand-rolling
+// row-major insertion here.
+func expandSymmetric(m mat.Matrix) mat.Matrix {
+	sym := symmetryOf(m)
+	if sym == Full {
+		return m
+	}
+	sp, ok := m.(Sparser)
+	if !ok {
+		return m
+	}
+
+	rows, cols := m.Dims()
+	coo := NewCOO(rows, cols)
+	coo.Reserve(2 * sp.NNZ())
+
+	sign := 1.0
+	if sym == SkewSymmetric {
+		sign = -1.0
+	}
+	sp.DoNonZero(func(i, j int, v float64) {
+		coo.Append(i, j, v)
+		if i != j {
+			coo.Append(j, i, sign*v)
+		}
+	})
+
+	if _, isCSC := m.(*CSC); isCSC {
+		return coo.ToCSC()
+	}
+	return coo.ToCSR()
+}
+
+// reconcileSymmetry rejects operands flagged with different symmetric
+// storage modes (there is no single triangle that could represent both),
+// and expands any symmetric operand into a full temporary workspace so
+// the rest of Mul/addScaled can keep assuming full storage on both sides.
+//
+// addCSRCSR/addCSR merely union the sparsity patterns of a and b, so when
+// the receiver c is flagged with the same symmetric mode as both operands
+// the result is still a valid half-stored matrix and expansion can be
+// skipped; that's only true when aSym and bSym actually match c.symmetry -
+// a stale or mismatched receiver tag must not suppress expansion, since
+// the raw union addCSRCSR/addCSR then write would no longer agree with
+// the mode c claims to store. mulCSRCSR and friends have no symmetry
+// awareness at all and read raw stored triangles, so a Mul receiver's own
+// symmetry tag must never suppress expansion of its operands -
+// skipAlreadyReconciled must be false whenever reconcileSymmetry is
+// called on behalf of Mul.
+func (c *CSR) reconcileSymmetry(a, b mat.Matrix, skipAlreadyReconciled bool) (mat.Matrix, mat.Matrix) {
+	aSym, bSym := symmetryOf(a), symmetryOf(b)
+	if aSym != Full && bSym != Full && aSym != bSym {
+		panic("sparse: cannot combine operands with different symmetric storage modes")
+	}
+
+	if skipAlreadyReconciled && c.symmetry != Full && aSym == c.symmetry && bSym == c.symmetry {
+		return a, b
+	}
+	if aSym != Full {
+		a = expandSymmetric(a)
+	}
+	if bSym != Full {
+		b = expandSymmetric(b)
+	}
+	return a, b
+}